@@ -0,0 +1,7 @@
+package domain
+
+// Entity is implemented by every domain type that a generic
+// repository.Repository[T] can scan out of a Neo4j node.
+type Entity interface {
+	EntityID() string
+}
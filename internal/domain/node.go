@@ -0,0 +1,25 @@
+package domain
+
+import "time"
+
+// NodeType identifies the Neo4j label applied to a Node in addition to the
+// generic :Node label (e.g. "Concept", "Article", "Author").
+type NodeType string
+
+// Node is a vertex in the knowledge graph. The neo4j tags map fields onto
+// Node property names for repository.ScanIntoStruct; Tags is populated
+// separately from the HAS_TAG relationships, not a node property.
+type Node struct {
+	ID        string    `neo4j:"id"`
+	Title     string    `neo4j:"title"`
+	Content   string    `neo4j:"content"`
+	Type      NodeType  `neo4j:"type"`
+	Tags      []string  `neo4j:"-"`
+	CreatedAt time.Time `neo4j:"created_at"`
+	UpdatedAt time.Time `neo4j:"updated_at"`
+}
+
+// EntityID implements domain.Entity.
+func (n Node) EntityID() string {
+	return n.ID
+}
@@ -0,0 +1,23 @@
+package domain
+
+import "time"
+
+// RelationshipType identifies the Neo4j relationship type connecting two
+// nodes (e.g. "RELATES_TO", "REFERENCES", "AUTHORED_BY").
+type RelationshipType string
+
+// Relationship is a directed edge from SourceID to each of TargetIDs.
+type Relationship struct {
+	ID        string
+	SourceID  string
+	TargetIDs []string
+	// IDs optionally supplies one caller-generated UUID per TargetIDs
+	// entry. repository.NodeRepository.CreateRelationship upserts on these
+	// ids (MERGE ... ON CREATE/ON MATCH), so retrying a call with the same
+	// IDs after a timeout reuses the existing edges instead of creating
+	// duplicates. If nil, CreateRelationship generates one id per target.
+	IDs         []string
+	Type        RelationshipType
+	Description string
+	CreatedAt   time.Time
+}
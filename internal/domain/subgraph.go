@@ -0,0 +1,21 @@
+package domain
+
+import "time"
+
+// Edge is a single directed relationship reached while traversing a
+// Subgraph.
+type Edge struct {
+	ID          string
+	SourceID    string
+	TargetID    string
+	Type        RelationshipType
+	Description string
+	CreatedAt   time.Time
+}
+
+// Subgraph is the deduplicated set of nodes and edges reached from a root
+// node within a bounded traversal depth.
+type Subgraph struct {
+	Nodes []*Node
+	Edges []*Edge
+}
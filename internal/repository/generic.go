@@ -0,0 +1,203 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"reflect"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/AndrivA89/knowledge-manager/internal/domain"
+)
+
+// InvalidLabelError is returned when a Repository's label doesn't look like
+// a bare Cypher identifier. NewRepository doesn't validate its argument, so
+// FindByID checks it the same way SchemaRegistry.NodeLabel checks a
+// domain.NodeType, since label is spliced into the query text the same way.
+type InvalidLabelError struct {
+	Label string
+}
+
+func (e *InvalidLabelError) Error() string {
+	return fmt.Sprintf("repository: invalid label %q", e.Label)
+}
+
+// Repository is a generic, read-oriented Neo4j repository for any domain
+// type tagged with `neo4j:"..."` struct tags. It lets new entity types
+// (Tag, Category, Author, ...) be looked up by ID without hand-writing a
+// record.Get/type-assertion scan for each one; NodeRepository keeps its own
+// bespoke write methods, which need label-merge and tag-edge handling that
+// don't generalize across entities.
+type Repository[T domain.Entity] struct {
+	driver neo4j.DriverWithContext
+	label  string
+}
+
+// NewRepository builds a generic repository for entities stored under the
+// given Neo4j label (e.g. "Tag", "Author").
+func NewRepository[T domain.Entity](driver neo4j.DriverWithContext, label string) *Repository[T] {
+	return &Repository[T]{
+		driver: driver,
+		label:  label,
+	}
+}
+
+// FindByID loads the entity with the given id, scanning its properties
+// directly into T via ScanIntoStruct. skip names fields (by their neo4j
+// tag) that the caller will populate separately, e.g. relationships that
+// aren't plain node properties.
+func (r *Repository[T]) FindByID(ctx context.Context, id string, skip []string) (*T, error) {
+	if !relTypeIdentifier.MatchString(r.label) {
+		return nil, &InvalidLabelError{Label: r.label}
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer func(session neo4j.SessionWithContext, ctx context.Context) {
+		if err := session.Close(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}(session, ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := fmt.Sprintf(`MATCH (n:%s {id: $id}) RETURN n`, r.label)
+
+		cyRes, err := tx.Run(ctx, query, map[string]interface{}{"id": id})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := cyRes.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		node, err := ParseValueFromRecord[neo4j.Node](record, "n")
+		if err != nil {
+			return nil, err
+		}
+
+		dest := new(T)
+		if err := ScanIntoStruct(&node, dest, skip); err != nil {
+			return nil, err
+		}
+
+		return dest, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return result.(*T), nil
+}
+
+// ScanIntoStruct copies a Neo4j node's properties into dest, a pointer to a
+// struct whose fields carry `neo4j:"propName"` tags. Fields tagged `neo4j:"-"`
+// and fields named in skip are left untouched, so callers can populate them
+// separately (e.g. from a collect(...) of related nodes).
+func ScanIntoStruct(node *neo4j.Node, dest any, skip []string) error {
+	v := reflect.ValueOf(dest)
+	if v.Kind() != reflect.Ptr || v.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("neo4j: ScanIntoStruct: dest must be a pointer to a struct, got %T", dest)
+	}
+
+	skipSet := make(map[string]struct{}, len(skip))
+	for _, s := range skip {
+		skipSet[s] = struct{}{}
+	}
+
+	elem := v.Elem()
+	t := elem.Type()
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		tag := field.Tag.Get("neo4j")
+		if tag == "" || tag == "-" {
+			continue
+		}
+		if _, ok := skipSet[tag]; ok {
+			continue
+		}
+
+		raw, ok := node.Props[tag]
+		if !ok {
+			continue
+		}
+
+		fv := elem.Field(i)
+		if !fv.CanSet() {
+			continue
+		}
+
+		if err := setFieldValue(fv, raw); err != nil {
+			return fmt.Errorf("neo4j: ScanIntoStruct: field %q: %w", field.Name, err)
+		}
+	}
+
+	return nil
+}
+
+func setFieldValue(fv reflect.Value, raw interface{}) error {
+	switch fv.Kind() {
+	case reflect.String:
+		s, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("expected string, got %T", raw)
+		}
+		fv.SetString(s)
+		return nil
+	default:
+		rv := reflect.ValueOf(raw)
+		if !rv.Type().AssignableTo(fv.Type()) {
+			return fmt.Errorf("expected %s, got %T", fv.Type(), raw)
+		}
+		fv.Set(rv)
+		return nil
+	}
+}
+
+// ParseValueFromRecord reads column key from rec and asserts it to T,
+// replacing the `val, _ := record.Get(key); val.(T)` boilerplate repeated
+// across the repository with a single checked call.
+func ParseValueFromRecord[T any](rec *neo4j.Record, key string) (T, error) {
+	var zero T
+
+	raw, ok := rec.Get(key)
+	if !ok {
+		return zero, fmt.Errorf("neo4j: column %q not found in record", key)
+	}
+
+	val, ok := raw.(T)
+	if !ok {
+		return zero, fmt.Errorf("neo4j: column %q: expected %T, got %T", key, zero, raw)
+	}
+
+	return val, nil
+}
+
+// ParseIDsFromRecord reads a []interface{} column of strings (typically a
+// collect(x.id)) out of rec and returns it as []string. resourceType is
+// included in error messages to say which query produced a malformed column.
+func ParseIDsFromRecord(rec *neo4j.Record, key string, resourceType string) ([]string, error) {
+	raw, ok := rec.Get(key)
+	if !ok {
+		return nil, fmt.Errorf("neo4j: %s: column %q not found in record", resourceType, key)
+	}
+
+	items, ok := raw.([]interface{})
+	if !ok {
+		return nil, fmt.Errorf("neo4j: %s: expected []interface{} for column %q, got %T", resourceType, key, raw)
+	}
+
+	ids := make([]string, 0, len(items))
+	for _, item := range items {
+		id, ok := item.(string)
+		if !ok {
+			return nil, fmt.Errorf("neo4j: %s: expected string id, got %T", resourceType, item)
+		}
+		ids = append(ids, id)
+	}
+
+	return ids, nil
+}
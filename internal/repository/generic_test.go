@@ -0,0 +1,108 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/AndrivA89/knowledge-manager/internal/domain"
+)
+
+func TestScanIntoStruct(t *testing.T) {
+	createdAt := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	n := &neo4j.Node{
+		Props: map[string]interface{}{
+			"id":         "node-1",
+			"title":      "A title",
+			"content":    "Some content",
+			"type":       "Concept",
+			"created_at": createdAt,
+			"updated_at": createdAt,
+		},
+	}
+
+	node := &domain.Node{}
+	if err := ScanIntoStruct(n, node, nil); err != nil {
+		t.Fatalf("ScanIntoStruct returned error: %v", err)
+	}
+
+	if node.ID != "node-1" || node.Title != "A title" || node.Content != "Some content" {
+		t.Errorf("ScanIntoStruct populated node = %+v, want id/title/content from Props", node)
+	}
+	if node.Type != domain.NodeType("Concept") {
+		t.Errorf("node.Type = %q, want %q", node.Type, "Concept")
+	}
+	if !node.CreatedAt.Equal(createdAt) {
+		t.Errorf("node.CreatedAt = %v, want %v", node.CreatedAt, createdAt)
+	}
+}
+
+func TestScanIntoStruct_Skip(t *testing.T) {
+	n := &neo4j.Node{
+		Props: map[string]interface{}{
+			"id":    "node-1",
+			"title": "should be skipped",
+		},
+	}
+
+	node := &domain.Node{Title: "original"}
+	if err := ScanIntoStruct(n, node, []string{"title"}); err != nil {
+		t.Fatalf("ScanIntoStruct returned error: %v", err)
+	}
+
+	if node.Title != "original" {
+		t.Errorf("node.Title = %q, want unchanged %q", node.Title, "original")
+	}
+	if node.ID != "node-1" {
+		t.Errorf("node.ID = %q, want %q", node.ID, "node-1")
+	}
+}
+
+func TestScanIntoStruct_MissingProp(t *testing.T) {
+	n := &neo4j.Node{Props: map[string]interface{}{"id": "node-1"}}
+
+	node := &domain.Node{Title: "untouched"}
+	if err := ScanIntoStruct(n, node, nil); err != nil {
+		t.Fatalf("ScanIntoStruct returned error: %v", err)
+	}
+
+	if node.Title != "untouched" {
+		t.Errorf("node.Title = %q, want untouched field left as %q", node.Title, "untouched")
+	}
+}
+
+func TestScanIntoStruct_DestNotPointerToStruct(t *testing.T) {
+	n := &neo4j.Node{Props: map[string]interface{}{"id": "node-1"}}
+
+	if err := ScanIntoStruct(n, domain.Node{}, nil); err == nil {
+		t.Error("ScanIntoStruct(non-pointer dest) = nil error, want error")
+	}
+
+	node := domain.Node{}
+	if err := ScanIntoStruct(n, &node.ID, nil); err == nil {
+		t.Error("ScanIntoStruct(pointer to non-struct) = nil error, want error")
+	}
+}
+
+func TestScanIntoStruct_FieldTypeMismatch(t *testing.T) {
+	n := &neo4j.Node{Props: map[string]interface{}{"id": 42}}
+
+	node := &domain.Node{}
+	if err := ScanIntoStruct(n, node, nil); err == nil {
+		t.Error("ScanIntoStruct(int for string field) = nil error, want error")
+	}
+}
+
+func TestRepository_FindByID_InvalidLabel(t *testing.T) {
+	repo := NewRepository[domain.Node](nil, "Bad; DROP")
+
+	_, err := repo.FindByID(context.Background(), "node-1", nil)
+
+	var invalidErr *InvalidLabelError
+	if !errors.As(err, &invalidErr) {
+		t.Fatalf("FindByID with invalid label error = %v, want *InvalidLabelError", err)
+	}
+}
@@ -2,25 +2,129 @@ package repository
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
 	"fmt"
 	"log"
+	"regexp"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
 	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
 
 	"github.com/AndrivA89/knowledge-manager/internal/domain"
 )
 
+// NodeSortKey is a whitelisted property a ListNodes query may sort on. Only
+// values declared here are ever spliced into the generated Cypher, so an
+// arbitrary caller-supplied string can never reach the query text.
+type NodeSortKey string
+
+const (
+	SortByCreatedAt NodeSortKey = "created_at"
+	SortByUpdatedAt NodeSortKey = "updated_at"
+	SortByTitle     NodeSortKey = "title"
+)
+
+var nodeSortKeyColumn = map[NodeSortKey]string{
+	SortByCreatedAt: "created_at",
+	SortByUpdatedAt: "updated_at",
+	SortByTitle:     "title",
+}
+
+// nodeSortKeyIsDateTime marks the sort keys whose underlying property is a
+// Neo4j datetime() value rather than a plain string, so cursor comparisons
+// know to wrap the bound parameter with datetime(...) to stay comparable.
+var nodeSortKeyIsDateTime = map[NodeSortKey]bool{
+	SortByCreatedAt: true,
+	SortByUpdatedAt: true,
+	SortByTitle:     false,
+}
+
+// TagMatchMode controls whether NodeFilter.Tags is interpreted as an AND
+// (all tags present) or OR (any tag present) condition.
+type TagMatchMode string
+
+const (
+	TagMatchAny TagMatchMode = "any"
+	TagMatchAll TagMatchMode = "all"
+)
+
+// NodeFilter narrows a ListNodes query. Zero-value fields are not applied.
+type NodeFilter struct {
+	Type          *domain.NodeType
+	Tags          []string
+	TagMatch      TagMatchMode
+	TitleContains string
+	CreatedFrom   *time.Time
+	CreatedTo     *time.Time
+	UpdatedFrom   *time.Time
+	UpdatedTo     *time.Time
+}
+
+// Pagination configures sorting and paging for ListNodes. If Cursor is set
+// it takes precedence over Offset: the query resumes strictly after the
+// node the cursor points at, which stays stable across concurrent inserts.
+// Otherwise Offset/Limit behave like a plain SKIP/LIMIT page.
+type Pagination struct {
+	SortKey  NodeSortKey
+	SortDesc bool
+	Offset   int
+	Limit    int
+	Cursor   string
+}
+
+// nodeCursor is the decoded payload of a Pagination.Cursor.
+type nodeCursor struct {
+	SortValue string `json:"sort_value"`
+	ID        string `json:"id"`
+}
+
+// EncodeNodeCursor builds an opaque, base64-encoded cursor from the last row
+// of a page so the next page can resume after it.
+func EncodeNodeCursor(sortValue, id string) string {
+	raw, _ := json.Marshal(nodeCursor{SortValue: sortValue, ID: id})
+	return base64.URLEncoding.EncodeToString(raw)
+}
+
+func decodeNodeCursor(cursor string) (*nodeCursor, error) {
+	raw, err := base64.URLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	var c nodeCursor
+	if err := json.Unmarshal(raw, &c); err != nil {
+		return nil, fmt.Errorf("invalid cursor: %w", err)
+	}
+
+	return &c, nil
+}
+
 type NodeRepository struct {
 	driver neo4j.DriverWithContext
+	schema *SchemaRegistry
 }
 
-func NewNodeRepository(driver neo4j.DriverWithContext) *NodeRepository {
+// NewNodeRepository builds a NodeRepository. schema is the whitelist
+// CreateNode and CreateRelationship validate node/relationship types
+// against before splicing them into Cypher as a label or relationship type;
+// pass NewDefaultSchemaRegistry() to accept the types documented on
+// domain.NodeType and domain.RelationshipType, or build a custom
+// *SchemaRegistry to restrict writes to a different set.
+func NewNodeRepository(driver neo4j.DriverWithContext, schema *SchemaRegistry) *NodeRepository {
 	return &NodeRepository{
 		driver: driver,
+		schema: schema,
 	}
 }
 
+// CreateNode writes node, generating a UUID for node.ID if the caller hasn't
+// already set one. The write is a MERGE keyed on that id rather than a plain
+// CREATE, so calling CreateNode again with the same id (e.g. an
+// application-level retry after a timeout, or an outbox worker replaying a
+// message) updates the existing node instead of creating a duplicate.
 func (r *NodeRepository) CreateNode(ctx context.Context, node *domain.Node) (string, error) {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer func(session neo4j.SessionWithContext, ctx context.Context) {
@@ -31,30 +135,183 @@ func (r *NodeRepository) CreateNode(ctx context.Context, node *domain.Node) (str
 	}(session, ctx)
 
 	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		node.CreatedAt = time.Now()
-		node.UpdatedAt = time.Now()
+		return r.createNode(ctx, tx, node)
+	})
+
+	if err != nil {
+		return "", err
+	}
+
+	return result.(string), nil
+}
+
+// createNode runs CreateNode's write against an already-open tx, so WithTx
+// callers can batch it alongside other writes in a single transaction.
+func (r *NodeRepository) createNode(ctx context.Context, tx neo4j.ManagedTransaction, node *domain.Node) (string, error) {
+	label, err := r.schema.NodeLabel(node.Type)
+	if err != nil {
+		return "", err
+	}
+
+	if node.ID == "" {
+		node.ID = uuid.NewString()
+	}
+	node.CreatedAt = time.Now()
+	node.UpdatedAt = time.Now()
+
+	query := `
+		MERGE (n:Node {id: $id})
+		ON CREATE SET
+			n.created_at = datetime($created_at),
+			n.title = $title,
+			n.content = $content,
+			n.type = $type,
+			n.updated_at = datetime($updated_at)
+		ON MATCH SET
+			n.title = $title,
+			n.content = $content,
+			n.type = $type,
+			n.updated_at = datetime($updated_at)
+		SET n:` + label + `
+		WITH n
+		OPTIONAL MATCH (n)-[r:HAS_TAG]->(:Tag)
+		DELETE r
+		WITH DISTINCT n
+		LIMIT 1
+		FOREACH (tag IN $tags | MERGE (t:Tag {name: tag}) MERGE (n)-[:HAS_TAG]->(t))
+		RETURN n.id as id
+	`
+
+	params := map[string]interface{}{
+		"id":         node.ID,
+		"title":      node.Title,
+		"content":    node.Content,
+		"type":       string(node.Type),
+		"created_at": node.CreatedAt.Format(time.RFC3339),
+		"updated_at": node.UpdatedAt.Format(time.RFC3339),
+		"tags":       node.Tags,
+	}
+
+	result, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return "", err
+	}
+
+	record, err := result.Single(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	return ParseValueFromRecord[string](record, "id")
+}
+
+// CreateRelationship creates one edge per rel.TargetIDs from rel.SourceID,
+// keyed on rel.IDs if the caller supplied one per target, or on a generated
+// UUID otherwise. As with CreateNode, the write is a MERGE rather than a
+// CREATE, so retrying with the same ids updates the existing edges instead
+// of duplicating them.
+func (r *NodeRepository) CreateRelationship(ctx context.Context, rel *domain.Relationship) ([]string, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer func(session neo4j.SessionWithContext, ctx context.Context) {
+		err := session.Close(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}(session, ctx)
+
+	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		return r.createRelationship(ctx, tx, rel)
+	})
+
+	if err != nil {
+		return nil, err
+	}
+
+	return result.([]string), nil
+}
+
+// createRelationship runs CreateRelationship's write against an
+// already-open tx, so WithTx callers can batch it alongside other writes in
+// a single transaction.
+func (r *NodeRepository) createRelationship(ctx context.Context, tx neo4j.ManagedTransaction, rel *domain.Relationship) ([]string, error) {
+	relType, err := r.schema.RelationshipTypeName(rel.Type)
+	if err != nil {
+		return nil, err
+	}
+
+	rel.CreatedAt = time.Now()
+
+	// Generated ids are written back onto rel (the caller's shared pointer)
+	// rather than kept in a local variable, so a retry of this function by
+	// session.ExecuteWrite sees the ids already set and reuses them instead
+	// of drawing fresh ones, keeping the MERGE below idempotent across
+	// retries the same way createNode's node.ID mutation does.
+	if len(rel.IDs) != len(rel.TargetIDs) {
+		rel.IDs = make([]string, len(rel.TargetIDs))
+		for i := range rel.IDs {
+			rel.IDs[i] = uuid.NewString()
+		}
+	}
+
+	rows := make([]map[string]interface{}, len(rel.TargetIDs))
+	for i, targetID := range rel.TargetIDs {
+		rows[i] = map[string]interface{}{
+			"id":        rel.IDs[i],
+			"target_id": targetID,
+		}
+	}
+
+	query := `
+		MATCH (source:Node {id: $source_id})
+		UNWIND $rows AS row
+		MATCH (target:Node {id: row.target_id})
+		MERGE (source)-[r:` + relType + ` {id: row.id}]->(target)
+		ON CREATE SET
+			r.created_at = datetime($created_at),
+			r.description = $description
+		ON MATCH SET
+			r.description = $description
+		RETURN collect(r.id) as ids
+	`
+
+	params := map[string]interface{}{
+		"source_id":   rel.SourceID,
+		"rows":        rows,
+		"description": rel.Description,
+		"created_at":  rel.CreatedAt.Format(time.RFC3339),
+	}
+
+	cyRes, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return nil, err
+	}
+
+	record, err := cyRes.Single(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParseIDsFromRecord(record, "ids", "relationship")
+}
+
+func (r *NodeRepository) GetNodeByID(ctx context.Context, id string) (*domain.Node, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer func(session neo4j.SessionWithContext, ctx context.Context) {
+		err := session.Close(ctx)
+		if err != nil {
+			log.Fatal(err)
+		}
+	}(session, ctx)
 
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
 		query := `
-			CREATE (n:Node {
-				id: randomUUID(),
-				title: $title,
-				content: $content,
-				type: $type,
-				created_at: datetime($created_at),
-				updated_at: datetime($updated_at)
-			})
-			SET n:` + string(node.Type) + `
-			FOREACH (tag IN $tags | MERGE (t:Tag {name: tag}) MERGE (n)-[:HAS_TAG]->(t))
-			RETURN n.id as id
+			MATCH (n:Node {id: $id})
+			OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
+			RETURN n, collect(t.name) as tags
 		`
 
 		params := map[string]interface{}{
-			"title":      node.Title,
-			"content":    node.Content,
-			"type":       string(node.Type),
-			"created_at": node.CreatedAt.Format(time.RFC3339),
-			"updated_at": node.UpdatedAt.Format(time.RFC3339),
-			"tags":       node.Tags,
+			"id": id,
 		}
 
 		result, err := tx.Run(ctx, query, params)
@@ -67,19 +324,41 @@ func (r *NodeRepository) CreateNode(ctx context.Context, node *domain.Node) (str
 			return nil, err
 		}
 
-		id, _ := record.Get("id")
-		return id, nil
+		n, err := ParseValueFromRecord[neo4j.Node](record, "n")
+		if err != nil {
+			return nil, err
+		}
+
+		node := &domain.Node{}
+		if err := ScanIntoStruct(&n, node, nil); err != nil {
+			return nil, err
+		}
+
+		tags, err := ParseValueFromRecord[[]interface{}](record, "tags")
+		if err != nil {
+			return nil, err
+		}
+		for _, tag := range tags {
+			node.Tags = append(node.Tags, tag.(string))
+		}
+
+		return node, nil
 	})
 
 	if err != nil {
-		return "", err
+		return nil, err
 	}
 
-	return result.(string), nil
+	return result.(*domain.Node), nil
 }
 
-func (r *NodeRepository) CreateRelationship(ctx context.Context, rel *domain.Relationship) ([]string, error) {
-	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+// ListNodes returns a page of nodes matching filter, ordered and paged
+// according to page, together with the total number of matching nodes
+// (ignoring the page window). Each node's tags are fetched alongside it via
+// a single OPTIONAL MATCH ... collect(...), avoiding an N+1 round trip per
+// node.
+func (r *NodeRepository) ListNodes(ctx context.Context, filter NodeFilter, page Pagination) ([]*domain.Node, int, error) {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer func(session neo4j.SessionWithContext, ctx context.Context) {
 		err := session.Close(ctx)
 		if err != nil {
@@ -87,61 +366,257 @@ func (r *NodeRepository) CreateRelationship(ctx context.Context, rel *domain.Rel
 		}
 	}(session, ctx)
 
-	result, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		rel.CreatedAt = time.Now()
+	sortKey := page.SortKey
+	if sortKey == "" {
+		sortKey = SortByCreatedAt
+	}
+	sortColumn, ok := nodeSortKeyColumn[sortKey]
+	if !ok {
+		return nil, 0, fmt.Errorf("unsupported sort key %q", sortKey)
+	}
+	sortDir := "ASC"
+	if page.SortDesc {
+		sortDir = "DESC"
+	}
 
-		query := `
-			MATCH (source:Node {id: $source_id})
-			UNWIND $target_ids AS tID
-			MATCH (target:Node {id: tID})
-			CREATE (source)-[r:` + string(rel.Type) + ` {
-				id: randomUUID(),
-				description: $description,
-				created_at: datetime($created_at)
-			}]->(target)
-			RETURN collect(r.id) as ids
-		`
+	where, params, err := buildNodeFilterClause(filter)
+	if err != nil {
+		return nil, 0, err
+	}
 
-		params := map[string]interface{}{
-			"source_id":   rel.SourceID,
-			"target_ids":  rel.TargetIDs,
-			"description": rel.Description,
-			"created_at":  rel.CreatedAt.Format(time.RFC3339),
+	limit := page.Limit
+	if limit <= 0 {
+		limit = 50
+	}
+
+	// The count query below must reflect filter alone, ignoring the page
+	// window, so it's built from where/params before the cursor predicate
+	// (which only bounds the page query) is mixed in.
+	countWhereClause := ""
+	if len(where) > 0 {
+		countWhereClause = "WHERE " + strings.Join(where, " AND ")
+	}
+
+	countResult, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		query := fmt.Sprintf(`
+			MATCH (n:Node)
+			%s
+			RETURN count(n) as total
+		`, countWhereClause)
+
+		result, err := tx.Run(ctx, query, params)
+		if err != nil {
+			return nil, err
 		}
 
-		cyRes, err := tx.Run(ctx, query, params)
+		record, err := result.Single(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		record, err := cyRes.Single(ctx)
+		total, _ := record.Get("total")
+		return total.(int64), nil
+	})
+	if err != nil {
+		return nil, 0, err
+	}
+	total := int(countResult.(int64))
+
+	pageWhere := append([]string{}, where...)
+	pageParams := make(map[string]interface{}, len(params)+3)
+	for k, v := range params {
+		pageParams[k] = v
+	}
+
+	if page.Cursor != "" {
+		cursor, err := decodeNodeCursor(page.Cursor)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		pageWhere = append(pageWhere, buildCursorPredicate(sortColumn, page.SortDesc, nodeSortKeyIsDateTime[sortKey]))
+		pageParams["cursor_value"] = cursor.SortValue
+		pageParams["cursor_id"] = cursor.ID
+	}
+
+	pageWhereClause := ""
+	if len(pageWhere) > 0 {
+		pageWhereClause = "WHERE " + strings.Join(pageWhere, " AND ")
+	}
+
+	pageParams["limit"] = limit
+	if page.Cursor == "" {
+		pageParams["offset"] = page.Offset
+	}
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		skipClause := ""
+		if page.Cursor == "" {
+			skipClause = "SKIP $offset"
+		}
+
+		query := fmt.Sprintf(`
+			MATCH (n:Node)
+			%s
+			WITH n
+			ORDER BY n.%s %s, n.id %s
+			%s
+			LIMIT $limit
+			OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
+			RETURN n, collect(t.name) as tags
+		`, pageWhereClause, sortColumn, sortDir, sortDir, skipClause)
+
+		cyRes, err := tx.Run(ctx, query, pageParams)
 		if err != nil {
 			return nil, err
 		}
 
-		idsVal, _ := record.Get("ids")
-		idsSlice, ok := idsVal.([]interface{})
-		if !ok {
-			return nil, fmt.Errorf("unexpected type for 'ids' column")
+		records, err := cyRes.Collect(ctx)
+		if err != nil {
+			return nil, err
 		}
 
-		var relIDs []string
-		for _, v := range idsSlice {
-			if s, ok := v.(string); ok {
-				relIDs = append(relIDs, s)
+		nodes := make([]*domain.Node, 0, len(records))
+		for _, record := range records {
+			n, err := ParseValueFromRecord[neo4j.Node](record, "n")
+			if err != nil {
+				return nil, err
 			}
+
+			node := &domain.Node{}
+			if err := ScanIntoStruct(&n, node, nil); err != nil {
+				return nil, err
+			}
+
+			tags, err := ParseValueFromRecord[[]interface{}](record, "tags")
+			if err != nil {
+				return nil, err
+			}
+			for _, tag := range tags {
+				node.Tags = append(node.Tags, tag.(string))
+			}
+
+			nodes = append(nodes, node)
 		}
-		return relIDs, nil
-	})
 
+		return nodes, nil
+	})
 	if err != nil {
-		return nil, err
+		return nil, 0, err
 	}
 
-	return result.([]string), nil
+	return result.([]*domain.Node), total, nil
 }
 
-func (r *NodeRepository) GetNodeByID(ctx context.Context, id string) (*domain.Node, error) {
+// buildCursorPredicate returns the keyset-pagination predicate ListNodes ANDs
+// into its page query once a cursor is decoded: rows strictly after the
+// cursor's (sort value, id) pair, in the same direction as the page's
+// ORDER BY. Cypher has no SQL-style row-value constructor — a parenthesized
+// expression holds exactly one Expression, never a comma list — so the
+// comparison is expanded into the equivalent OR-chain instead of
+// "(n.col, n.id) > (val, id)".
+func buildCursorPredicate(sortColumn string, sortDesc bool, isDateTime bool) string {
+	cmp := ">"
+	if sortDesc {
+		cmp = "<"
+	}
+	cursorValue := "$cursor_value"
+	if isDateTime {
+		cursorValue = "datetime($cursor_value)"
+	}
+
+	return fmt.Sprintf(
+		"(n.%s %s %s OR (n.%s = %s AND n.id %s $cursor_id))",
+		sortColumn, cmp, cursorValue, sortColumn, cursorValue, cmp,
+	)
+}
+
+// buildNodeFilterClause translates filter into a list of Cypher WHERE
+// predicates (ANDed together by the caller) plus their bound parameters.
+// Every value is passed as a query parameter; nothing from filter is ever
+// spliced into the query text.
+func buildNodeFilterClause(filter NodeFilter) ([]string, map[string]interface{}, error) {
+	var where []string
+	params := map[string]interface{}{}
+
+	if filter.Type != nil {
+		where = append(where, "n.type = $type")
+		params["type"] = string(*filter.Type)
+	}
+
+	if filter.TitleContains != "" {
+		where = append(where, "toLower(n.title) CONTAINS toLower($title_contains)")
+		params["title_contains"] = filter.TitleContains
+	}
+
+	if filter.CreatedFrom != nil {
+		where = append(where, "n.created_at >= datetime($created_from)")
+		params["created_from"] = filter.CreatedFrom.Format(time.RFC3339)
+	}
+	if filter.CreatedTo != nil {
+		where = append(where, "n.created_at <= datetime($created_to)")
+		params["created_to"] = filter.CreatedTo.Format(time.RFC3339)
+	}
+	if filter.UpdatedFrom != nil {
+		where = append(where, "n.updated_at >= datetime($updated_from)")
+		params["updated_from"] = filter.UpdatedFrom.Format(time.RFC3339)
+	}
+	if filter.UpdatedTo != nil {
+		where = append(where, "n.updated_at <= datetime($updated_to)")
+		params["updated_to"] = filter.UpdatedTo.Format(time.RFC3339)
+	}
+
+	if len(filter.Tags) > 0 {
+		quantifier := "ANY"
+		if filter.TagMatch == TagMatchAll {
+			quantifier = "ALL"
+		}
+		where = append(where, fmt.Sprintf(
+			"%s(tag IN $tags WHERE EXISTS { (n)-[:HAS_TAG]->(:Tag {name: tag}) })", quantifier))
+		params["tags"] = filter.Tags
+	}
+
+	return where, params, nil
+}
+
+// maxSubgraphDepth caps the variable-length pattern in GetSubgraph so an
+// unbounded depth can't turn a single query into a full graph scan.
+const maxSubgraphDepth = 5
+
+var relTypeIdentifier = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+// GetSubgraph traverses up to depth hops out from rootID over relTypes (any
+// relationship type if relTypes is empty) and returns every node and edge
+// reached, deduplicated. It runs as a single Cypher round trip instead of
+// walking the neighborhood one relationship at a time.
+//
+// relTypes is the caller's whitelist: each entry is checked against
+// relTypeIdentifier before being spliced into the pattern, since Neo4j has
+// no way to parameterize a relationship type in a MATCH pattern. Where APOC
+// is installed, apoc.path.subgraphAll(root, config) performs the same
+// traversal with its own cycle-safe uniqueness tracking and can replace the
+// plain-Cypher query below; it isn't required here so the method keeps
+// working against a driver-only deployment.
+func (r *NodeRepository) GetSubgraph(ctx context.Context, rootID string, depth int, relTypes []domain.RelationshipType) (*domain.Subgraph, error) {
+	if depth < 1 {
+		return nil, fmt.Errorf("depth must be at least 1, got %d", depth)
+	}
+	if depth > maxSubgraphDepth {
+		depth = maxSubgraphDepth
+	}
+
+	relFilter := ""
+	if len(relTypes) > 0 {
+		names := make([]string, len(relTypes))
+		for i, t := range relTypes {
+			if !relTypeIdentifier.MatchString(string(t)) {
+				return nil, fmt.Errorf("invalid relationship type %q", t)
+			}
+			names[i] = string(t)
+		}
+		relFilter = ":" + strings.Join(names, "|")
+	}
+
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
 	defer func(session neo4j.SessionWithContext, ctx context.Context) {
 		err := session.Close(ctx)
@@ -151,63 +626,120 @@ func (r *NodeRepository) GetNodeByID(ctx context.Context, id string) (*domain.No
 	}(session, ctx)
 
 	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		query := `
-			MATCH (n:Node {id: $id})
+		// edges is built with a list comprehension rather than UNWIND +
+		// collect(DISTINCT ...): UNWIND over an empty list yields zero rows,
+		// which would drop the whole record (and the root node along with
+		// it) whenever rootID has no matching relationship within depth.
+		// rawRels can contain the same relationship more than once (distinct
+		// paths through it), so the Go side dedupes edges by id instead.
+		query := fmt.Sprintf(`
+			MATCH (root:Node {id: $root_id})
+			OPTIONAL MATCH p = (root)-[r%s*1..%d]-(m)
+			WITH root, collect(p) as paths
+			WITH root,
+			     reduce(ns = [root], p IN paths | ns + CASE WHEN p IS NULL THEN [] ELSE nodes(p) END) as rawNodes,
+			     reduce(rs = [], p IN paths | rs + CASE WHEN p IS NULL THEN [] ELSE relationships(p) END) as rawRels
+			UNWIND rawNodes as n
+			WITH DISTINCT n, rawRels
 			OPTIONAL MATCH (n)-[:HAS_TAG]->(t:Tag)
-			RETURN n.id as id, n.title as title, n.content as content, n.type as type, 
-				   n.created_at as created_at, n.updated_at as updated_at, collect(t.name) as tags
-		`
+			WITH rawRels, n, collect(t.name) as tags
+			WITH rawRels, collect({node: n, tags: tags}) as nodeTags
+			RETURN nodeTags,
+			       [rel IN rawRels | {id: rel.id, type: type(rel), description: rel.description,
+			                          created_at: rel.created_at,
+			                          source_id: startNode(rel).id, target_id: endNode(rel).id}] as edges
+		`, relFilter, depth)
 
 		params := map[string]interface{}{
-			"id": id,
+			"root_id": rootID,
 		}
 
-		result, err := tx.Run(ctx, query, params)
+		cyRes, err := tx.Run(ctx, query, params)
 		if err != nil {
 			return nil, err
 		}
 
-		record, err := result.Single(ctx)
+		record, err := cyRes.Single(ctx)
 		if err != nil {
 			return nil, err
 		}
 
-		node := &domain.Node{}
-
-		idVal, _ := record.Get("id")
-		node.ID = idVal.(string)
+		subgraph := &domain.Subgraph{}
 
-		titleVal, _ := record.Get("title")
-		node.Title = titleVal.(string)
+		nodeTagsVal, _ := record.Get("nodeTags")
+		for _, raw := range nodeTagsVal.([]interface{}) {
+			entry := raw.(map[string]interface{})
+			n := entry["node"].(neo4j.Node)
 
-		contentVal, _ := record.Get("content")
-		node.Content = contentVal.(string)
-
-		nodeType, _ := record.Get("type")
-		node.Type = domain.NodeType(nodeType.(string))
-
-		createdAt, _ := record.Get("created_at")
-		updatedAt, _ := record.Get("updated_at")
+			node := &domain.Node{}
+			if err := ScanIntoStruct(&n, node, nil); err != nil {
+				return nil, err
+			}
+			for _, tag := range entry["tags"].([]interface{}) {
+				node.Tags = append(node.Tags, tag.(string))
+			}
+			subgraph.Nodes = append(subgraph.Nodes, node)
+		}
 
-		node.CreatedAt = createdAt.(time.Time)
-		node.UpdatedAt = updatedAt.(time.Time)
+		edgesVal, _ := record.Get("edges")
+		seenEdges := make(map[string]bool, len(edgesVal.([]interface{})))
+		for _, raw := range edgesVal.([]interface{}) {
+			entry := raw.(map[string]interface{})
+			id := entry["id"].(string)
+			if seenEdges[id] {
+				continue
+			}
+			seenEdges[id] = true
 
-		tags, _ := record.Get("tags")
-		for _, tag := range tags.([]interface{}) {
-			node.Tags = append(node.Tags, tag.(string))
+			subgraph.Edges = append(subgraph.Edges, &domain.Edge{
+				ID:          id,
+				SourceID:    entry["source_id"].(string),
+				TargetID:    entry["target_id"].(string),
+				Type:        domain.RelationshipType(entry["type"].(string)),
+				Description: entry["description"].(string),
+				CreatedAt:   entry["created_at"].(time.Time),
+			})
 		}
 
-		return node, nil
+		return subgraph, nil
 	})
-
 	if err != nil {
 		return nil, err
 	}
 
-	return result.(*domain.Node), nil
+	return result.(*domain.Subgraph), nil
 }
 
-func (r *NodeRepository) UpdateNode(ctx context.Context, node *domain.Node) error {
+// nodeFullTextIndex is the Neo4j full-text schema index name created by
+// Migrate and queried by SearchNodes.
+const nodeFullTextIndex = "node_fts"
+
+const (
+	defaultSearchLimit  = 20
+	defaultSnippetChars = 160
+)
+
+// SearchOptions configures SearchNodes.
+type SearchOptions struct {
+	// Limit caps the number of hits returned, highest Lucene score first.
+	// Defaults to defaultSearchLimit.
+	Limit int
+	// SnippetChars is the approximate length of each highlighted snippet.
+	// Defaults to defaultSnippetChars.
+	SnippetChars int
+}
+
+// SearchHit is a single full-text match: the matched node, its Lucene
+// relevance score, and a highlighted excerpt per matched field.
+type SearchHit struct {
+	Node     *domain.Node
+	Score    float64
+	Snippets []string
+}
+
+// Migrate creates the full-text schema index SearchNodes depends on. It is
+// idempotent (IF NOT EXISTS) and safe to call on every startup.
+func (r *NodeRepository) Migrate(ctx context.Context) error {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer func(session neo4j.SessionWithContext, ctx context.Context) {
 		if err := session.Close(ctx); err != nil {
@@ -216,47 +748,225 @@ func (r *NodeRepository) UpdateNode(ctx context.Context, node *domain.Node) erro
 	}(session, ctx)
 
 	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		node.UpdatedAt = time.Now()
+		query := fmt.Sprintf(`
+			CREATE FULLTEXT INDEX %s IF NOT EXISTS FOR (n:Node) ON EACH [n.title, n.content]
+		`, nodeFullTextIndex)
 
-		query := `
-			MATCH (n:Node {id: $id})
-			SET n.title = $title,
-			    n.content = $content,
-			    n.type = $type,
-			    n.updated_at = datetime($updated_at)
-			WITH n
-			OPTIONAL MATCH (n)-[r:HAS_TAG]->(:Tag)
-			DELETE r
-			WITH DISTINCT n
-			LIMIT 1
-			FOREACH (tag IN $tags |
-				MERGE (t:Tag {name: tag})
-				MERGE (n)-[:HAS_TAG]->(t)
-			)
-			RETURN n
+		_, err := tx.Run(ctx, query, nil)
+		return nil, err
+	})
+
+	return err
+}
+
+// SearchNodes runs query against the full-text index created by Migrate.
+// query is passed straight through to Lucene, so callers can use phrases
+// ("exact phrase"), fuzzy matching (term~), and boolean operators (AND, OR,
+// NOT) as documented by Neo4j's db.index.fulltext.queryNodes.
+func (r *NodeRepository) SearchNodes(ctx context.Context, query string, opts SearchOptions) ([]SearchHit, error) {
+	limit := opts.Limit
+	if limit <= 0 {
+		limit = defaultSearchLimit
+	}
+	snippetChars := opts.SnippetChars
+	if snippetChars <= 0 {
+		snippetChars = defaultSnippetChars
+	}
+
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeRead})
+	defer func(session neo4j.SessionWithContext, ctx context.Context) {
+		if err := session.Close(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}(session, ctx)
+
+	result, err := session.ExecuteRead(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		cyQuery := `
+			CALL db.index.fulltext.queryNodes($index, $query) YIELD node, score
+			WITH node, score ORDER BY score DESC LIMIT $limit
+			OPTIONAL MATCH (node)-[:HAS_TAG]->(t:Tag)
+			RETURN node, score, collect(t.name) as tags
 		`
 
 		params := map[string]interface{}{
-			"id":         node.ID,
-			"title":      node.Title,
-			"content":    node.Content,
-			"type":       string(node.Type),
-			"updated_at": node.UpdatedAt.Format(time.RFC3339),
-			"tags":       node.Tags,
+			"index": nodeFullTextIndex,
+			"query": query,
+			"limit": limit,
 		}
 
-		result, err := tx.Run(ctx, query, params)
+		cyRes, err := tx.Run(ctx, cyQuery, params)
 		if err != nil {
 			return nil, err
 		}
 
-		_, err = result.Single(ctx)
+		records, err := cyRes.Collect(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		hits := make([]SearchHit, 0, len(records))
+		for _, record := range records {
+			n, err := ParseValueFromRecord[neo4j.Node](record, "node")
+			if err != nil {
+				return nil, err
+			}
+
+			node := &domain.Node{}
+			if err := ScanIntoStruct(&n, node, nil); err != nil {
+				return nil, err
+			}
+
+			tags, err := ParseValueFromRecord[[]interface{}](record, "tags")
+			if err != nil {
+				return nil, err
+			}
+			for _, tag := range tags {
+				node.Tags = append(node.Tags, tag.(string))
+			}
+
+			score, err := ParseValueFromRecord[float64](record, "score")
+			if err != nil {
+				return nil, err
+			}
+
+			hits = append(hits, SearchHit{
+				Node:     node,
+				Score:    score,
+				Snippets: buildSearchSnippets(node, query, snippetChars),
+			})
+		}
+
+		return hits, nil
+	})
+	if err != nil {
 		return nil, err
+	}
+
+	return result.([]SearchHit), nil
+}
+
+var searchTermPattern = regexp.MustCompile(`[A-Za-z0-9_]+`)
+
+// extractSearchTerms pulls the plain words out of a Lucene query string,
+// dropping boolean operators so they aren't highlighted as if they were
+// search terms.
+func extractSearchTerms(query string) []string {
+	terms := make([]string, 0)
+	for _, word := range searchTermPattern.FindAllString(query, -1) {
+		switch strings.ToUpper(word) {
+		case "AND", "OR", "NOT":
+			continue
+		}
+		terms = append(terms, word)
+	}
+	return terms
+}
+
+// buildSearchSnippets returns one highlighted excerpt per field (title,
+// content) that contains a search term, emphasizing the matched word with
+// "**...**" the way the rest of the snippet is plain text.
+func buildSearchSnippets(node *domain.Node, query string, window int) []string {
+	terms := extractSearchTerms(query)
+
+	var snippets []string
+	for _, field := range []string{node.Title, node.Content} {
+		if snippet, ok := highlightSnippet(field, terms, window); ok {
+			snippets = append(snippets, snippet)
+		}
+	}
+	return snippets
+}
+
+// highlightSnippet finds term case-insensitively directly in text (rather
+// than lower-casing a copy and reusing its byte offsets against the
+// original) because strings.ToLower isn't byte-length-preserving for every
+// rune: a folded offset can misalign with text, or index past its end and
+// panic, for inputs like "İstanbul".
+func highlightSnippet(text string, terms []string, window int) (string, bool) {
+	for _, term := range terms {
+		pattern, err := regexp.Compile("(?i)" + regexp.QuoteMeta(term))
+		if err != nil {
+			continue
+		}
+
+		loc := pattern.FindStringIndex(text)
+		if loc == nil {
+			continue
+		}
+		matchStart, matchEnd := loc[0], loc[1]
+
+		start := matchStart - window/2
+		if start < 0 {
+			start = 0
+		}
+		end := matchEnd + window/2
+		if end > len(text) {
+			end = len(text)
+		}
+
+		snippet := text[start:matchStart] + "**" + text[matchStart:matchEnd] + "**" + text[matchEnd:end]
+		return strings.TrimSpace(snippet), true
+	}
+
+	return "", false
+}
+
+func (r *NodeRepository) UpdateNode(ctx context.Context, node *domain.Node) error {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer func(session neo4j.SessionWithContext, ctx context.Context) {
+		if err := session.Close(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}(session, ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		return nil, r.updateNode(ctx, tx, node)
 	})
 
 	return err
 }
 
+// updateNode runs UpdateNode's write against an already-open tx, so WithTx
+// callers can batch it alongside other writes in a single transaction.
+func (r *NodeRepository) updateNode(ctx context.Context, tx neo4j.ManagedTransaction, node *domain.Node) error {
+	node.UpdatedAt = time.Now()
+
+	query := `
+		MATCH (n:Node {id: $id})
+		SET n.title = $title,
+		    n.content = $content,
+		    n.type = $type,
+		    n.updated_at = datetime($updated_at)
+		WITH n
+		OPTIONAL MATCH (n)-[r:HAS_TAG]->(:Tag)
+		DELETE r
+		WITH DISTINCT n
+		LIMIT 1
+		FOREACH (tag IN $tags |
+			MERGE (t:Tag {name: tag})
+			MERGE (n)-[:HAS_TAG]->(t)
+		)
+		RETURN n
+	`
+
+	params := map[string]interface{}{
+		"id":         node.ID,
+		"title":      node.Title,
+		"content":    node.Content,
+		"type":       string(node.Type),
+		"updated_at": node.UpdatedAt.Format(time.RFC3339),
+		"tags":       node.Tags,
+	}
+
+	result, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return err
+	}
+
+	_, err = result.Single(ctx)
+	return err
+}
+
 func (r *NodeRepository) DeleteNode(ctx context.Context, id string) error {
 	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
 	defer func(session neo4j.SessionWithContext, ctx context.Context) {
@@ -267,24 +977,30 @@ func (r *NodeRepository) DeleteNode(ctx context.Context, id string) error {
 	}(session, ctx)
 
 	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
-		query := `
-			MATCH (n:Node {id: $id})
-			DETACH DELETE n
-		`
+		return nil, r.deleteNode(ctx, tx, id)
+	})
 
-		params := map[string]interface{}{
-			"id": id,
-		}
+	return err
+}
 
-		result, err := tx.Run(ctx, query, params)
-		if err != nil {
-			return nil, err
-		}
+// deleteNode runs DeleteNode's write against an already-open tx, so WithTx
+// callers can batch it alongside other writes in a single transaction.
+func (r *NodeRepository) deleteNode(ctx context.Context, tx neo4j.ManagedTransaction, id string) error {
+	query := `
+		MATCH (n:Node {id: $id})
+		DETACH DELETE n
+	`
 
-		_, err = result.Consume(ctx)
-		return nil, err
-	})
+	params := map[string]interface{}{
+		"id": id,
+	}
+
+	result, err := tx.Run(ctx, query, params)
+	if err != nil {
+		return err
+	}
 
+	_, err = result.Consume(ctx)
 	return err
 }
 
@@ -0,0 +1,178 @@
+package repository
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/AndrivA89/knowledge-manager/internal/domain"
+)
+
+func TestEncodeDecodeNodeCursor(t *testing.T) {
+	cursor := EncodeNodeCursor("2024-01-02T15:04:05Z", "node-123")
+
+	decoded, err := decodeNodeCursor(cursor)
+	if err != nil {
+		t.Fatalf("decodeNodeCursor returned error: %v", err)
+	}
+
+	if decoded.SortValue != "2024-01-02T15:04:05Z" {
+		t.Errorf("SortValue = %q, want %q", decoded.SortValue, "2024-01-02T15:04:05Z")
+	}
+	if decoded.ID != "node-123" {
+		t.Errorf("ID = %q, want %q", decoded.ID, "node-123")
+	}
+}
+
+func TestDecodeNodeCursor_Invalid(t *testing.T) {
+	cases := []string{
+		"not-base64!!!",
+		"bm90LWpzb24=", // base64("not-json"), not valid JSON
+	}
+
+	for _, cursor := range cases {
+		if _, err := decodeNodeCursor(cursor); err == nil {
+			t.Errorf("decodeNodeCursor(%q) = nil error, want error", cursor)
+		}
+	}
+}
+
+func TestBuildNodeFilterClause(t *testing.T) {
+	concept := domain.NodeType("Concept")
+	createdFrom := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name       string
+		filter     NodeFilter
+		wantWhere  int
+		wantParams map[string]interface{}
+	}{
+		{
+			name:       "empty filter produces no predicates",
+			filter:     NodeFilter{},
+			wantWhere:  0,
+			wantParams: map[string]interface{}{},
+		},
+		{
+			name:       "type filter",
+			filter:     NodeFilter{Type: &concept},
+			wantWhere:  1,
+			wantParams: map[string]interface{}{"type": "Concept"},
+		},
+		{
+			name:       "created from filter",
+			filter:     NodeFilter{CreatedFrom: &createdFrom},
+			wantWhere:  1,
+			wantParams: map[string]interface{}{"created_from": createdFrom.Format(time.RFC3339)},
+		},
+		{
+			name:       "tags any",
+			filter:     NodeFilter{Tags: []string{"a", "b"}, TagMatch: TagMatchAny},
+			wantWhere:  1,
+			wantParams: map[string]interface{}{"tags": []string{"a", "b"}},
+		},
+		{
+			name:       "tags all",
+			filter:     NodeFilter{Tags: []string{"a", "b"}, TagMatch: TagMatchAll},
+			wantWhere:  1,
+			wantParams: map[string]interface{}{"tags": []string{"a", "b"}},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			where, params, err := buildNodeFilterClause(tc.filter)
+			if err != nil {
+				t.Fatalf("buildNodeFilterClause returned error: %v", err)
+			}
+
+			if len(where) != tc.wantWhere {
+				t.Errorf("len(where) = %d, want %d (where=%v)", len(where), tc.wantWhere, where)
+			}
+
+			for k, want := range tc.wantParams {
+				got, ok := params[k]
+				if !ok {
+					t.Errorf("params[%q] missing", k)
+					continue
+				}
+
+				gotSlice, gotIsSlice := got.([]string)
+				wantSlice, wantIsSlice := want.([]string)
+				if gotIsSlice && wantIsSlice {
+					if len(gotSlice) != len(wantSlice) {
+						t.Errorf("params[%q] = %v, want %v", k, got, want)
+					}
+					continue
+				}
+
+				if got != want {
+					t.Errorf("params[%q] = %v, want %v", k, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestBuildCursorPredicate(t *testing.T) {
+	cases := []struct {
+		name       string
+		sortColumn string
+		sortDesc   bool
+		isDateTime bool
+		want       string
+	}{
+		{
+			name:       "ascending plain column",
+			sortColumn: "title",
+			want:       "(n.title > $cursor_value OR (n.title = $cursor_value AND n.id > $cursor_id))",
+		},
+		{
+			name:       "descending plain column",
+			sortColumn: "title",
+			sortDesc:   true,
+			want:       "(n.title < $cursor_value OR (n.title = $cursor_value AND n.id < $cursor_id))",
+		},
+		{
+			name:       "ascending datetime column",
+			sortColumn: "created_at",
+			isDateTime: true,
+			want:       "(n.created_at > datetime($cursor_value) OR (n.created_at = datetime($cursor_value) AND n.id > $cursor_id))",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := buildCursorPredicate(tc.sortColumn, tc.sortDesc, tc.isDateTime)
+			if got != tc.want {
+				t.Errorf("buildCursorPredicate(%q, %v, %v) = %q, want %q",
+					tc.sortColumn, tc.sortDesc, tc.isDateTime, got, tc.want)
+			}
+
+			// Cypher parenthesized expressions hold exactly one Expression,
+			// never a comma list — guard against regressing to the
+			// SQL-style row-value form this replaced.
+			if strings.Contains(got, ", n.id)") {
+				t.Errorf("buildCursorPredicate emitted a row-value comma list: %q", got)
+			}
+		})
+	}
+}
+
+func TestBuildNodeFilterClause_TagQuantifier(t *testing.T) {
+	whereAny, _, err := buildNodeFilterClause(NodeFilter{Tags: []string{"a"}, TagMatch: TagMatchAny})
+	if err != nil {
+		t.Fatalf("buildNodeFilterClause returned error: %v", err)
+	}
+	if len(whereAny) != 1 || whereAny[0][:3] != "ANY" {
+		t.Errorf("expected ANY quantifier, got %v", whereAny)
+	}
+
+	whereAll, _, err := buildNodeFilterClause(NodeFilter{Tags: []string{"a"}, TagMatch: TagMatchAll})
+	if err != nil {
+		t.Fatalf("buildNodeFilterClause returned error: %v", err)
+	}
+	if len(whereAll) != 1 || whereAll[0][:3] != "ALL" {
+		t.Errorf("expected ALL quantifier, got %v", whereAll)
+	}
+}
@@ -0,0 +1,106 @@
+package repository
+
+import (
+	"fmt"
+
+	"github.com/AndrivA89/knowledge-manager/internal/domain"
+)
+
+// UnknownNodeTypeError is returned when a NodeType hasn't been registered
+// with SchemaRegistry.RegisterNodeType.
+type UnknownNodeTypeError struct {
+	NodeType domain.NodeType
+}
+
+func (e *UnknownNodeTypeError) Error() string {
+	return fmt.Sprintf("repository: unknown node type %q", e.NodeType)
+}
+
+// UnknownRelationshipTypeError is returned when a RelationshipType hasn't
+// been registered with SchemaRegistry.RegisterRelationshipType.
+type UnknownRelationshipTypeError struct {
+	RelationshipType domain.RelationshipType
+}
+
+func (e *UnknownRelationshipTypeError) Error() string {
+	return fmt.Sprintf("repository: unknown relationship type %q", e.RelationshipType)
+}
+
+// SchemaRegistry is the whitelist CreateNode and CreateRelationship check a
+// node/relationship type against before splicing it into Cypher as a label
+// or relationship type. Neo4j has no way to parameterize either, so without
+// this check an unrecognized type is either a Cypher-injection vector or a
+// query that crashes at run time instead of failing with a typed error up
+// front.
+type SchemaRegistry struct {
+	nodeTypes map[domain.NodeType]struct{}
+	relTypes  map[domain.RelationshipType]struct{}
+}
+
+// NewSchemaRegistry builds an empty registry. Call RegisterNodeType and
+// RegisterRelationshipType to declare the types a NodeRepository accepts.
+func NewSchemaRegistry() *SchemaRegistry {
+	return &SchemaRegistry{
+		nodeTypes: make(map[domain.NodeType]struct{}),
+		relTypes:  make(map[domain.RelationshipType]struct{}),
+	}
+}
+
+// NewDefaultSchemaRegistry returns a SchemaRegistry pre-populated with the
+// node and relationship types used as examples in the domain package's doc
+// comments (domain.NodeType, domain.RelationshipType).
+func NewDefaultSchemaRegistry() *SchemaRegistry {
+	reg := NewSchemaRegistry()
+
+	reg.RegisterNodeType("Concept")
+	reg.RegisterNodeType("Article")
+	reg.RegisterNodeType("Author")
+
+	reg.RegisterRelationshipType("RELATES_TO")
+	reg.RegisterRelationshipType("REFERENCES")
+	reg.RegisterRelationshipType("AUTHORED_BY")
+
+	return reg
+}
+
+// RegisterNodeType declares nodeType as valid. It's a no-op if nodeType is
+// already registered.
+func (s *SchemaRegistry) RegisterNodeType(nodeType domain.NodeType) {
+	s.nodeTypes[nodeType] = struct{}{}
+}
+
+// RegisterRelationshipType declares relType as valid. It's a no-op if
+// relType is already registered.
+func (s *SchemaRegistry) RegisterRelationshipType(relType domain.RelationshipType) {
+	s.relTypes[relType] = struct{}{}
+}
+
+// NodeLabel returns the Cypher label to splice into a query for nodeType.
+// It fails closed: nodeType must both be registered and look like a bare
+// Cypher identifier, since RegisterNodeType itself doesn't validate its
+// input.
+func (s *SchemaRegistry) NodeLabel(nodeType domain.NodeType) (string, error) {
+	if _, ok := s.nodeTypes[nodeType]; !ok {
+		return "", &UnknownNodeTypeError{NodeType: nodeType}
+	}
+	if !relTypeIdentifier.MatchString(string(nodeType)) {
+		return "", &UnknownNodeTypeError{NodeType: nodeType}
+	}
+
+	return string(nodeType), nil
+}
+
+// RelationshipTypeName returns the Cypher relationship type to splice into
+// a query for relType. It fails closed: relType must both be registered and
+// look like a bare Cypher identifier, since RegisterRelationshipType itself
+// doesn't validate its input.
+func (s *SchemaRegistry) RelationshipTypeName(relType domain.RelationshipType) (string, error) {
+	if _, ok := s.relTypes[relType]; !ok {
+		return "", &UnknownRelationshipTypeError{RelationshipType: relType}
+	}
+	if !relTypeIdentifier.MatchString(string(relType)) {
+		return "", &UnknownRelationshipTypeError{RelationshipType: relType}
+	}
+
+	return string(relType), nil
+}
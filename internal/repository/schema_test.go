@@ -0,0 +1,84 @@
+package repository
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/AndrivA89/knowledge-manager/internal/domain"
+)
+
+func TestSchemaRegistry_NodeLabel(t *testing.T) {
+	reg := NewSchemaRegistry()
+	reg.RegisterNodeType("Concept")
+	reg.RegisterNodeType("Bad; DROP")
+
+	cases := []struct {
+		name     string
+		nodeType domain.NodeType
+		want     string
+		wantErr  bool
+	}{
+		{name: "unregistered type", nodeType: "Article", wantErr: true},
+		{name: "registered but not an identifier", nodeType: "Bad; DROP", wantErr: true},
+		{name: "registered identifier", nodeType: "Concept", want: "Concept"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			label, err := reg.NodeLabel(tc.nodeType)
+
+			if tc.wantErr {
+				var unknownErr *UnknownNodeTypeError
+				if !errors.As(err, &unknownErr) {
+					t.Fatalf("NodeLabel(%q) error = %v, want *UnknownNodeTypeError", tc.nodeType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("NodeLabel(%q) returned error: %v", tc.nodeType, err)
+			}
+			if label != tc.want {
+				t.Errorf("NodeLabel(%q) = %q, want %q", tc.nodeType, label, tc.want)
+			}
+		})
+	}
+}
+
+func TestSchemaRegistry_RelationshipTypeName(t *testing.T) {
+	reg := NewSchemaRegistry()
+	reg.RegisterRelationshipType("RELATES_TO")
+	reg.RegisterRelationshipType("BAD TYPE")
+
+	cases := []struct {
+		name    string
+		relType domain.RelationshipType
+		want    string
+		wantErr bool
+	}{
+		{name: "unregistered type", relType: "REFERENCES", wantErr: true},
+		{name: "registered but not an identifier", relType: "BAD TYPE", wantErr: true},
+		{name: "registered identifier", relType: "RELATES_TO", want: "RELATES_TO"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			name, err := reg.RelationshipTypeName(tc.relType)
+
+			if tc.wantErr {
+				var unknownErr *UnknownRelationshipTypeError
+				if !errors.As(err, &unknownErr) {
+					t.Fatalf("RelationshipTypeName(%q) error = %v, want *UnknownRelationshipTypeError", tc.relType, err)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("RelationshipTypeName(%q) returned error: %v", tc.relType, err)
+			}
+			if name != tc.want {
+				t.Errorf("RelationshipTypeName(%q) = %q, want %q", tc.relType, name, tc.want)
+			}
+		})
+	}
+}
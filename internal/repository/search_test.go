@@ -0,0 +1,62 @@
+package repository
+
+import "testing"
+
+func TestExtractSearchTerms(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		want  []string
+	}{
+		{name: "plain words", query: "graph database", want: []string{"graph", "database"}},
+		{name: "drops boolean operators", query: "graph AND database OR neo4j NOT cypher",
+			want: []string{"graph", "database", "neo4j", "cypher"}},
+		{name: "fuzzy suffix stripped from term", query: "grap~ database",
+			want: []string{"grap", "database"}},
+		{name: "empty query", query: "", want: []string{}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := extractSearchTerms(tc.query)
+
+			if len(got) != len(tc.want) {
+				t.Fatalf("extractSearchTerms(%q) = %v, want %v", tc.query, got, tc.want)
+			}
+			for i, term := range tc.want {
+				if got[i] != term {
+					t.Errorf("extractSearchTerms(%q)[%d] = %q, want %q", tc.query, i, got[i], term)
+				}
+			}
+		})
+	}
+}
+
+func TestHighlightSnippet(t *testing.T) {
+	text := "Graphs model relationships between entities as nodes and edges."
+
+	snippet, ok := highlightSnippet(text, []string{"relationships"}, 20)
+	if !ok {
+		t.Fatalf("highlightSnippet: no match found, want a match")
+	}
+	if want := "**relationships**"; !contains(snippet, want) {
+		t.Errorf("highlightSnippet = %q, want it to contain %q", snippet, want)
+	}
+
+	if _, ok := highlightSnippet(text, []string{"sql"}, 20); ok {
+		t.Errorf("highlightSnippet matched a term not present in the text")
+	}
+
+	if _, ok := highlightSnippet(text, nil, 20); ok {
+		t.Errorf("highlightSnippet matched with no terms to search for")
+	}
+}
+
+func contains(s, substr string) bool {
+	for i := 0; i+len(substr) <= len(s); i++ {
+		if s[i:i+len(substr)] == substr {
+			return true
+		}
+	}
+	return false
+}
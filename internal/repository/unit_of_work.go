@@ -0,0 +1,264 @@
+package repository
+
+import (
+	"context"
+	"log"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/neo4j/neo4j-go-driver/v5/neo4j"
+
+	"github.com/AndrivA89/knowledge-manager/internal/domain"
+)
+
+// UnitOfWork exposes NodeRepository's write operations against a single
+// shared transaction. WithTx is the only way to obtain one: every call made
+// through it runs in the same ManagedTransaction, so a caller ingesting many
+// nodes and relationships pays for one session and one transaction instead
+// of one per call, and a failure partway through rolls back everything
+// written so far instead of leaving a half-built subgraph behind.
+type UnitOfWork interface {
+	CreateNode(ctx context.Context, node *domain.Node) (string, error)
+	CreateRelationship(ctx context.Context, rel *domain.Relationship) ([]string, error)
+	UpdateNode(ctx context.Context, node *domain.Node) error
+	DeleteNode(ctx context.Context, id string) error
+
+	// CreateNodesBulk upserts nodes via one UNWIND $rows AS row MERGE ...
+	// per domain.NodeType instead of one query per node, so importing a
+	// large batch costs a handful of round trips rather than len(nodes).
+	CreateNodesBulk(ctx context.Context, nodes []*domain.Node) ([]string, error)
+	// CreateRelationshipsBulk is CreateNodesBulk's counterpart for edges,
+	// grouping by domain.RelationshipType for the same reason.
+	CreateRelationshipsBulk(ctx context.Context, rels []*domain.Relationship) ([]string, error)
+}
+
+// WithTx opens a single write transaction and runs fn against a UnitOfWork
+// backed by it. The transaction commits if fn returns nil and rolls back
+// (propagating fn's error) otherwise, so a batch of writes either lands
+// atomically or leaves no partial structure behind. Like the rest of the
+// driver's managed transactions, fn may be retried on a transient error and
+// should be idempotent.
+func (r *NodeRepository) WithTx(ctx context.Context, fn func(uow UnitOfWork) error) error {
+	session := r.driver.NewSession(ctx, neo4j.SessionConfig{AccessMode: neo4j.AccessModeWrite})
+	defer func(session neo4j.SessionWithContext, ctx context.Context) {
+		if err := session.Close(ctx); err != nil {
+			log.Fatal(err)
+		}
+	}(session, ctx)
+
+	_, err := session.ExecuteWrite(ctx, func(tx neo4j.ManagedTransaction) (interface{}, error) {
+		return nil, fn(&unitOfWork{repo: r, tx: tx})
+	})
+
+	return err
+}
+
+// unitOfWork is the UnitOfWork implementation WithTx hands to fn. It
+// delegates to NodeRepository's tx-level helpers so the Cypher lives in one
+// place whether it's run standalone (NodeRepository.CreateNode et al.) or
+// batched inside a transaction.
+type unitOfWork struct {
+	repo *NodeRepository
+	tx   neo4j.ManagedTransaction
+}
+
+func (u *unitOfWork) CreateNode(ctx context.Context, node *domain.Node) (string, error) {
+	return u.repo.createNode(ctx, u.tx, node)
+}
+
+func (u *unitOfWork) CreateRelationship(ctx context.Context, rel *domain.Relationship) ([]string, error) {
+	return u.repo.createRelationship(ctx, u.tx, rel)
+}
+
+func (u *unitOfWork) UpdateNode(ctx context.Context, node *domain.Node) error {
+	return u.repo.updateNode(ctx, u.tx, node)
+}
+
+func (u *unitOfWork) DeleteNode(ctx context.Context, id string) error {
+	return u.repo.deleteNode(ctx, u.tx, id)
+}
+
+func (u *unitOfWork) CreateNodesBulk(ctx context.Context, nodes []*domain.Node) ([]string, error) {
+	return u.repo.createNodesBulk(ctx, u.tx, nodes)
+}
+
+func (u *unitOfWork) CreateRelationshipsBulk(ctx context.Context, rels []*domain.Relationship) ([]string, error) {
+	return u.repo.createRelationshipsBulk(ctx, u.tx, rels)
+}
+
+// createNodesBulk upserts nodes via one UNWIND $rows AS row MERGE ... query
+// per NodeType. Neo4j has no way to parameterize a label, so rows are
+// grouped by Type (the same restriction CreateNode already works around by
+// splicing a single node's Type into the query) and each group is written
+// in one round trip instead of one MERGE per node.
+func (r *NodeRepository) createNodesBulk(ctx context.Context, tx neo4j.ManagedTransaction, nodes []*domain.Node) ([]string, error) {
+	groups, order := groupNodesByType(nodes)
+	now := time.Now()
+
+	ids := make([]string, 0, len(nodes))
+	for _, nodeType := range order {
+		label, err := r.schema.NodeLabel(nodeType)
+		if err != nil {
+			return nil, err
+		}
+
+		rows := make([]map[string]interface{}, len(groups[nodeType]))
+		for i, n := range groups[nodeType] {
+			if n.ID == "" {
+				n.ID = uuid.NewString()
+			}
+			n.CreatedAt = now
+			n.UpdatedAt = now
+			rows[i] = map[string]interface{}{
+				"id":         n.ID,
+				"title":      n.Title,
+				"content":    n.Content,
+				"type":       string(n.Type),
+				"tags":       n.Tags,
+				"created_at": n.CreatedAt.Format(time.RFC3339),
+				"updated_at": n.UpdatedAt.Format(time.RFC3339),
+			}
+		}
+
+		query := `
+			UNWIND $rows AS row
+			MERGE (n:Node {id: row.id})
+			ON CREATE SET
+				n.created_at = datetime(row.created_at),
+				n.title = row.title,
+				n.content = row.content,
+				n.type = row.type,
+				n.updated_at = datetime(row.updated_at)
+			ON MATCH SET
+				n.title = row.title,
+				n.content = row.content,
+				n.type = row.type,
+				n.updated_at = datetime(row.updated_at)
+			SET n:` + label + `
+			WITH n, row
+			OPTIONAL MATCH (n)-[r:HAS_TAG]->(:Tag)
+			DELETE r
+			WITH DISTINCT n, row
+			FOREACH (tag IN row.tags | MERGE (t:Tag {name: tag}) MERGE (n)-[:HAS_TAG]->(t))
+			RETURN collect(n.id) as ids
+		`
+
+		cyRes, err := tx.Run(ctx, query, map[string]interface{}{"rows": rows})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := cyRes.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		groupIDs, err := ParseIDsFromRecord(record, "ids", "node")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, groupIDs...)
+	}
+
+	return ids, nil
+}
+
+func groupNodesByType(nodes []*domain.Node) (map[domain.NodeType][]*domain.Node, []domain.NodeType) {
+	groups := make(map[domain.NodeType][]*domain.Node)
+	order := make([]domain.NodeType, 0)
+
+	for _, n := range nodes {
+		if _, ok := groups[n.Type]; !ok {
+			order = append(order, n.Type)
+		}
+		groups[n.Type] = append(groups[n.Type], n)
+	}
+
+	return groups, order
+}
+
+// createRelationshipsBulk is CreateRelationship's UNWIND counterpart,
+// grouping by RelationshipType for the same reason createNodesBulk groups
+// by NodeType: the relationship type can't be parameterized, only spliced.
+func (r *NodeRepository) createRelationshipsBulk(ctx context.Context, tx neo4j.ManagedTransaction, rels []*domain.Relationship) ([]string, error) {
+	groups, order := groupRelationshipsByType(rels)
+	now := time.Now()
+
+	ids := make([]string, 0, len(rels))
+	for _, relType := range order {
+		relTypeName, err := r.schema.RelationshipTypeName(relType)
+		if err != nil {
+			return nil, err
+		}
+
+		var rows []map[string]interface{}
+		for _, rel := range groups[relType] {
+			rel.CreatedAt = now
+
+			// Written back onto rel so a retry of this function by
+			// ExecuteWrite reuses the same ids instead of drawing fresh
+			// ones; see createRelationship's identical comment.
+			if len(rel.IDs) != len(rel.TargetIDs) {
+				rel.IDs = make([]string, len(rel.TargetIDs))
+				for i := range rel.IDs {
+					rel.IDs[i] = uuid.NewString()
+				}
+			}
+
+			for i, targetID := range rel.TargetIDs {
+				rows = append(rows, map[string]interface{}{
+					"id":          rel.IDs[i],
+					"source_id":   rel.SourceID,
+					"target_id":   targetID,
+					"description": rel.Description,
+					"created_at":  rel.CreatedAt.Format(time.RFC3339),
+				})
+			}
+		}
+
+		query := `
+			UNWIND $rows AS row
+			MATCH (source:Node {id: row.source_id})
+			MATCH (target:Node {id: row.target_id})
+			MERGE (source)-[r:` + relTypeName + ` {id: row.id}]->(target)
+			ON CREATE SET
+				r.created_at = datetime(row.created_at),
+				r.description = row.description
+			ON MATCH SET
+				r.description = row.description
+			RETURN collect(r.id) as ids
+		`
+
+		cyRes, err := tx.Run(ctx, query, map[string]interface{}{"rows": rows})
+		if err != nil {
+			return nil, err
+		}
+
+		record, err := cyRes.Single(ctx)
+		if err != nil {
+			return nil, err
+		}
+
+		groupIDs, err := ParseIDsFromRecord(record, "ids", "relationship")
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, groupIDs...)
+	}
+
+	return ids, nil
+}
+
+func groupRelationshipsByType(rels []*domain.Relationship) (map[domain.RelationshipType][]*domain.Relationship, []domain.RelationshipType) {
+	groups := make(map[domain.RelationshipType][]*domain.Relationship)
+	order := make([]domain.RelationshipType, 0)
+
+	for _, rel := range rels {
+		if _, ok := groups[rel.Type]; !ok {
+			order = append(order, rel.Type)
+		}
+		groups[rel.Type] = append(groups[rel.Type], rel)
+	}
+
+	return groups, order
+}
@@ -0,0 +1,68 @@
+package repository
+
+import (
+	"testing"
+
+	"github.com/AndrivA89/knowledge-manager/internal/domain"
+)
+
+func TestGroupNodesByType(t *testing.T) {
+	concept := &domain.Node{ID: "1", Type: "Concept"}
+	article1 := &domain.Node{ID: "2", Type: "Article"}
+	article2 := &domain.Node{ID: "3", Type: "Article"}
+
+	groups, order := groupNodesByType([]*domain.Node{concept, article1, article2})
+
+	wantOrder := []domain.NodeType{"Concept", "Article"}
+	if len(order) != len(wantOrder) {
+		t.Fatalf("order = %v, want %v", order, wantOrder)
+	}
+	for i, nt := range wantOrder {
+		if order[i] != nt {
+			t.Errorf("order[%d] = %q, want %q", i, order[i], nt)
+		}
+	}
+
+	if got := groups["Concept"]; len(got) != 1 || got[0] != concept {
+		t.Errorf("groups[Concept] = %v, want [%v]", got, concept)
+	}
+	if got := groups["Article"]; len(got) != 2 || got[0] != article1 || got[1] != article2 {
+		t.Errorf("groups[Article] = %v, want [%v %v]", got, article1, article2)
+	}
+}
+
+func TestGroupNodesByType_Empty(t *testing.T) {
+	groups, order := groupNodesByType(nil)
+
+	if len(order) != 0 {
+		t.Errorf("order = %v, want empty", order)
+	}
+	if len(groups) != 0 {
+		t.Errorf("groups = %v, want empty", groups)
+	}
+}
+
+func TestGroupRelationshipsByType(t *testing.T) {
+	relatesTo := &domain.Relationship{ID: "1", Type: "RELATES_TO"}
+	references1 := &domain.Relationship{ID: "2", Type: "REFERENCES"}
+	references2 := &domain.Relationship{ID: "3", Type: "REFERENCES"}
+
+	groups, order := groupRelationshipsByType([]*domain.Relationship{relatesTo, references1, references2})
+
+	wantOrder := []domain.RelationshipType{"RELATES_TO", "REFERENCES"}
+	if len(order) != len(wantOrder) {
+		t.Fatalf("order = %v, want %v", order, wantOrder)
+	}
+	for i, rt := range wantOrder {
+		if order[i] != rt {
+			t.Errorf("order[%d] = %q, want %q", i, order[i], rt)
+		}
+	}
+
+	if got := groups["RELATES_TO"]; len(got) != 1 || got[0] != relatesTo {
+		t.Errorf("groups[RELATES_TO] = %v, want [%v]", got, relatesTo)
+	}
+	if got := groups["REFERENCES"]; len(got) != 2 || got[0] != references1 || got[1] != references2 {
+		t.Errorf("groups[REFERENCES] = %v, want [%v %v]", got, references1, references2)
+	}
+}